@@ -0,0 +1,112 @@
+package ciaos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	ciaosErrors "github.com/vitality-ai/go-sdk/errors"
+)
+
+// APIError is returned by client methods whenever the server responds
+// with a status of 400 or above. It adds the operation and key that failed on
+// top of errors.ClientError, so callers can build retry logic or map
+// failures onto their own HTTP responses without re-parsing the
+// underlying error.
+//
+// Parameters:
+//
+//	StatusCode (int): The HTTP status code returned by the server.
+//	Op (string): The client operation that failed, e.g. "put", "get", "append".
+//	Key (string): The key the operation targeted, if any.
+//	Body ([]byte): The response body. Methods that go through the retry
+//	               loop store the decoded ClientError.Message here, which
+//	               is the raw body unless the server sent a structured
+//	               application/vnd.ciaos+json error.
+//	Err (error): The underlying error, typically an *errors.ClientError.
+type APIError struct {
+	StatusCode int
+	Op         string
+	Key        string
+	Body       []byte
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	if e.Key != "" {
+		return fmt.Sprintf("ciaos: %s %q: %v", e.Op, e.Key, e.Err)
+	}
+	return fmt.Sprintf("ciaos: %s: %v", e.Op, e.Err)
+}
+
+// Unwrap exposes the underlying error (typically an *errors.ClientError)
+// so errors.Is/errors.As can see through an APIError.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// wrapAPIError builds an *APIError for a failed response, decoding the
+// body via errors.Decode so Op/Key-aware callers get the same
+// Code/Message/RequestID fields doWithRetry already exposes to
+// retry logic.
+func wrapAPIError(op, key string, statusCode int, body []byte, contentType string) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Op:         op,
+		Key:        key,
+		Body:       body,
+		Err:        ciaosErrors.Decode(statusCode, body, contentType),
+	}
+}
+
+// wrapClientError adapts an error already produced by doWithRetry (an
+// *errors.ClientError) into an *APIError carrying op and key. Errors
+// that aren't a *ClientError (e.g. context cancellation) pass through
+// unchanged.
+func wrapClientError(op, key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var clientErr *ciaosErrors.ClientError
+	if !errors.As(err, &clientErr) {
+		return err
+	}
+	return &APIError{
+		StatusCode: clientErr.StatusCode,
+		Op:         op,
+		Key:        key,
+		Body:       []byte(clientErr.Message),
+		Err:        clientErr,
+	}
+}
+
+// IsNotFound reports whether err is an *APIError for a 404 response.
+func IsNotFound(err error) bool {
+	return apiErrorStatus(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an *APIError for a 401 response.
+func IsUnauthorized(err error) bool {
+	return apiErrorStatus(err) == http.StatusUnauthorized
+}
+
+// IsConflict reports whether err is an *APIError for a 409 response.
+func IsConflict(err error) bool {
+	return apiErrorStatus(err) == http.StatusConflict
+}
+
+// IsRetryable reports whether err is an *APIError whose status code is
+// one a caller could reasonably retry: 408 Request Timeout, 429 Too
+// Many Requests, or any 5xx.
+func IsRetryable(err error) bool {
+	status := apiErrorStatus(err)
+	return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
+
+func apiErrorStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
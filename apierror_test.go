@@ -0,0 +1,65 @@
+package ciaos_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestGetCtxReturnsTypedAPIErrorOnNotFound verifies that a 404 response
+// surfaces as a *ciaos.APIError with the operation, key, and status
+// code populated, so callers can classify it with IsNotFound instead
+// of string-matching an error message.
+func TestGetCtxReturnsTypedAPIErrorOnNotFound(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no such key"))
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser", RetryPolicy: ciaos.RetryPolicy{MaxAttempts: 1}}
+
+	_, err := cfg.GetCtx(context.Background(), "missingkey")
+	if err == nil {
+		t.Fatal("expected an error for a 404 response, got nil")
+	}
+
+	apiErr, ok := err.(*ciaos.APIError)
+	if !ok {
+		t.Fatalf("expected *ciaos.APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Op != "get" || apiErr.Key != "missingkey" {
+		t.Errorf("expected Op=get Key=missingkey, got Op=%s Key=%s", apiErr.Op, apiErr.Key)
+	}
+	if !ciaos.IsNotFound(err) {
+		t.Error("expected IsNotFound to report true")
+	}
+	if ciaos.IsRetryable(err) {
+		t.Error("expected IsRetryable to report false for a 404")
+	}
+}
+
+// TestIsRetryableClassifiesServerErrors verifies that 5xx and 429
+// responses are classified as retryable while a plain 400 is not.
+func TestIsRetryableClassifiesServerErrors(t *testing.T) {
+	retryable := &ciaos.APIError{StatusCode: http.StatusServiceUnavailable}
+	if !ciaos.IsRetryable(retryable) {
+		t.Error("expected 503 to be retryable")
+	}
+
+	throttled := &ciaos.APIError{StatusCode: http.StatusTooManyRequests}
+	if !ciaos.IsRetryable(throttled) {
+		t.Error("expected 429 to be retryable")
+	}
+
+	fatal := &ciaos.APIError{StatusCode: http.StatusBadRequest}
+	if ciaos.IsRetryable(fatal) {
+		t.Error("expected 400 to not be retryable")
+	}
+}
@@ -0,0 +1,316 @@
+package ciaos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// BatchItem describes a single object a caller wants to transfer as
+// part of a batch request.
+//
+// Parameters:
+//
+//	Key (string): The key of the object to transfer.
+//	Size (int64): The object's size in bytes, if known.
+//	Operation (string): Either "upload" or "download".
+//	Data ([]byte): The payload to send for an "upload" item. Ignored for
+//	               "download" items and never sent as part of the batch
+//	               manifest; it is only used as the body of the
+//	               subsequent transfer request.
+type BatchItem struct {
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	Operation string `json:"operation"`
+	Data      []byte `json:"-"`
+}
+
+// BatchAction is the server-issued transfer instruction for one
+// BatchItem, analogous to the "actions" object in the git-lfs batch
+// API.
+type BatchAction struct {
+	Href    string            `json:"href"`
+	Header  map[string]string `json:"header"`
+	Expires int64             `json:"expires_in"`
+}
+
+// BatchResult reports the outcome of transferring one BatchItem.
+//
+// Parameters:
+//
+//	Key (string): The key the result corresponds to.
+//	BytesTransferred (int64): Number of bytes sent or received for this item.
+//	Data ([]byte): The bytes received for a "download" item. Unset for uploads.
+//	Action (*BatchAction): The server action that was executed, if any.
+//	Err (error): Non-nil if the transfer for this item failed.
+type BatchResult struct {
+	Key              string
+	BytesTransferred int64
+	Data             []byte
+	Action           *BatchAction
+	Err              error
+}
+
+// ProgressFunc is called after every item in a batch finishes
+// transferring, reporting cumulative bytes moved so far out of total.
+type ProgressFunc func(done, total int64)
+
+// BatchOptions configures a Batch call.
+//
+// Parameters:
+//
+//	Concurrency (int): Maximum number of transfers in flight at once.
+//	                    Defaults to 4 when left at zero.
+//	OnProgress (ProgressFunc): Optional callback invoked as items complete.
+type BatchOptions struct {
+	Concurrency int
+	OnProgress  ProgressFunc
+}
+
+type batchManifest struct {
+	Operation string      `json:"operation"`
+	Items     []BatchItem `json:"items"`
+}
+
+type batchResponse struct {
+	Items []struct {
+		Key    string       `json:"key"`
+		Action *BatchAction `json:"action"`
+	} `json:"items"`
+}
+
+// Batch transfers items using the server's /batch endpoint: the client
+// first posts a manifest of {key, size, operation}, receives back a
+// per-object action (an upload or download URL with headers), and then
+// dispatches the transfers through a bounded worker pool. If the
+// server reports that /batch is not implemented, BatchOrLegacy
+// semantics kick in and the client falls back to issuing sequential
+// Put/Get calls against the existing endpoints, remembering the
+// fallback on Config so future Batch calls skip the round trip.
+//
+// Parameters:
+//
+//	operation (string): "upload" or "download", applied to every item.
+//	items ([]BatchItem): The objects to transfer.
+//	opts (...BatchOptions): Optional concurrency and progress settings.
+//
+// Returns:
+// ([]BatchResult): One result per item, in the same order as items.
+// (error): An error if the batch manifest itself could not be submitted.
+func (config *Config) Batch(operation string, items []BatchItem, opts ...BatchOptions) ([]BatchResult, error) {
+
+	options := BatchOptions{Concurrency: 4}
+	if len(opts) > 0 {
+		options = opts[0]
+		if options.Concurrency <= 0 {
+			options.Concurrency = 4
+		}
+	}
+
+	for i := range items {
+		items[i].Operation = operation
+	}
+
+	if config.batchUnsupported {
+		return config.batchLegacy(items, options)
+	}
+
+	actions, err := config.requestBatchActions(operation, items)
+	if err != nil {
+		if err == errBatchNotImplemented {
+			config.batchUnsupported = true
+			return config.batchLegacy(items, options)
+		}
+		return nil, err
+	}
+
+	return config.runBatch(items, actions, options)
+}
+
+var errBatchNotImplemented = fmt.Errorf("batch endpoint not implemented")
+
+// requestBatchActions posts the batch manifest and returns the
+// server-issued action for each key, keyed by item key.
+func (config *Config) requestBatchActions(operation string, items []BatchItem) (map[string]*BatchAction, error) {
+
+	body, err := json.Marshal(batchManifest{Operation: operation, Items: items})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode batch manifest: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/batch", config.APIURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error during batch request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotImplemented {
+		return nil, errBatchNotImplemented
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, wrapAPIError("batch", "", resp.StatusCode, respBody, resp.Header.Get("Content-Type"))
+	}
+
+	var parsed batchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode batch response: %v", err)
+	}
+
+	actions := make(map[string]*BatchAction, len(parsed.Items))
+	for _, item := range parsed.Items {
+		actions[item.Key] = item.Action
+	}
+
+	return actions, nil
+}
+
+// runBatch dispatches one transfer per item through a bounded worker
+// pool sized by options.Concurrency, reporting results in item order.
+func (config *Config) runBatch(items []BatchItem, actions map[string]*BatchAction, options BatchOptions) ([]BatchResult, error) {
+
+	results := make([]BatchResult, len(items))
+	jobs := make(chan int, len(items))
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		doneBytes int64
+	)
+
+	var totalBytes int64
+	for _, item := range items {
+		totalBytes += item.Size
+	}
+
+	for w := 0; w < options.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				item := items[i]
+				action := actions[item.Key]
+				result := config.transferBatchItem(item, action)
+
+				mu.Lock()
+				results[i] = result
+				doneBytes += result.BytesTransferred
+				if options.OnProgress != nil {
+					options.OnProgress(doneBytes, totalBytes)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// transferBatchItem executes a single BatchAction against the href and
+// headers the server returned for item, sending item.Data as the body
+// for an upload and collecting the response body into the result's
+// Data for a download.
+func (config *Config) transferBatchItem(item BatchItem, action *BatchAction) BatchResult {
+
+	if action == nil {
+		return BatchResult{Key: item.Key, Err: fmt.Errorf("no action returned for key %s", item.Key)}
+	}
+
+	method := "GET"
+	var body io.Reader
+	if item.Operation == "upload" {
+		method = "PUT"
+		body = bytes.NewReader(item.Data)
+	}
+
+	req, err := http.NewRequest(method, action.Href, body)
+	if err != nil {
+		return BatchResult{Key: item.Key, Action: action, Err: fmt.Errorf("failed to create transfer request: %v", err)}
+	}
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return BatchResult{Key: item.Key, Action: action, Err: fmt.Errorf("HTTP error during transfer: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return BatchResult{Key: item.Key, Action: action, Err: wrapAPIError("batch_transfer", item.Key, resp.StatusCode, respBody, resp.Header.Get("Content-Type"))}
+	}
+
+	if item.Operation == "upload" {
+		return BatchResult{Key: item.Key, Action: action, BytesTransferred: int64(len(item.Data))}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return BatchResult{Key: item.Key, Action: action, Err: fmt.Errorf("failed to read downloaded data: %v", err)}
+	}
+
+	return BatchResult{Key: item.Key, Action: action, Data: data, BytesTransferred: int64(len(data))}
+}
+
+// batchLegacy transfers items by issuing the existing Put/Get calls
+// sequentially, one item at a time, for servers that don't support
+// /batch.
+func (config *Config) batchLegacy(items []BatchItem, options BatchOptions) ([]BatchResult, error) {
+
+	results := make([]BatchResult, len(items))
+	var doneBytes, totalBytes int64
+	for _, item := range items {
+		totalBytes += item.Size
+	}
+
+	for i, item := range items {
+		switch item.Operation {
+		case "download":
+			chunks, err := config.Get(item.Key)
+			if err != nil {
+				results[i] = BatchResult{Key: item.Key, Err: err}
+				break
+			}
+			data := make([]byte, 0, item.Size)
+			for _, chunk := range chunks {
+				data = append(data, chunk...)
+			}
+			results[i] = BatchResult{Key: item.Key, Data: data, BytesTransferred: int64(len(data))}
+		default:
+			resp, err := config.PutBinary(item.Key, [][]byte{item.Data})
+			if err != nil {
+				results[i] = BatchResult{Key: item.Key, Err: err}
+				break
+			}
+			resp.Body.Close()
+			results[i] = BatchResult{Key: item.Key, BytesTransferred: int64(len(item.Data))}
+		}
+
+		doneBytes += results[i].BytesTransferred
+		if options.OnProgress != nil {
+			options.OnProgress(doneBytes, totalBytes)
+		}
+	}
+
+	return results, nil
+}
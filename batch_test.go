@@ -0,0 +1,233 @@
+package ciaos_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestBatchPartialFailure verifies that Batch reports a per-item error
+// for transfers that fail while still returning successful results for
+// the rest of the batch.
+func TestBatchPartialFailure(t *testing.T) {
+	var transferServer *httptest.Server
+	transferServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer transferServer.Close()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/batch" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{"key": "ok", "action": map[string]any{"href": transferServer.URL + "/ok"}},
+				{"key": "bad", "action": map[string]any{"href": transferServer.URL + "/fail"}},
+			},
+		})
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+	items := []ciaos.BatchItem{
+		{Key: "ok", Size: 10, Operation: "upload"},
+		{Key: "bad", Size: 10, Operation: "upload"},
+	}
+
+	results, err := cfg.Batch("upload", items)
+	if err != nil {
+		t.Fatalf("unexpected error from Batch: %v", err)
+	}
+
+	if results[0].Err != nil {
+		t.Errorf("expected item 'ok' to succeed, got error: %v", results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Errorf("expected item 'bad' to report an error")
+	}
+}
+
+// TestBatchLegacyFallback verifies that when the server reports 501 on
+// /batch, Batch falls back to the legacy per-item endpoints and
+// remembers the fallback for subsequent calls.
+func TestBatchLegacyFallback(t *testing.T) {
+	batchCalls := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/batch":
+			batchCalls++
+			w.WriteHeader(http.StatusNotImplemented)
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+	items := []ciaos.BatchItem{{Key: "onlyitem", Size: 4, Operation: "upload"}}
+
+	results, err := cfg.Batch("upload", items)
+	if err != nil {
+		t.Fatalf("unexpected error from Batch: %v", err)
+	}
+	if results[0].Err != nil {
+		t.Fatalf("expected legacy fallback to succeed, got: %v", results[0].Err)
+	}
+	if batchCalls != 1 {
+		t.Fatalf("expected exactly one /batch probe, got %d", batchCalls)
+	}
+
+	if _, err := cfg.Batch("upload", items); err != nil {
+		t.Fatalf("unexpected error on second Batch call: %v", err)
+	}
+	if batchCalls != 1 {
+		t.Fatalf("expected the server-unsupported flag to skip further /batch probes, got %d calls", batchCalls)
+	}
+}
+
+// TestBatchRespectsConcurrencyLimit verifies that BatchOptions.Concurrency
+// actually bounds the number of in-flight transfers: the transfer
+// server blocks each request until exactly Concurrency requests have
+// arrived at once, which only happens if the worker pool both reaches
+// and never exceeds that many simultaneous transfers.
+func TestBatchRespectsConcurrencyLimit(t *testing.T) {
+	const concurrency = 3
+	const itemCount = 9
+
+	var mu sync.Mutex
+	current := 0
+	maxSeen := 0
+	release := make(chan struct{})
+	var once sync.Once
+
+	transferServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if current > maxSeen {
+			maxSeen = current
+		}
+		reached := current == concurrency
+		mu.Unlock()
+
+		if reached {
+			once.Do(func() { close(release) })
+		}
+
+		select {
+		case <-release:
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer transferServer.Close()
+
+	mockItems := make([]map[string]any, itemCount)
+	batchItems := make([]ciaos.BatchItem, itemCount)
+	for i := range mockItems {
+		key := fmt.Sprintf("item%d", i)
+		mockItems[i] = map[string]any{"key": key, "action": map[string]any{"href": transferServer.URL}}
+		batchItems[i] = ciaos.BatchItem{Key: key, Size: 1}
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"items": mockItems})
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	if _, err := cfg.Batch("upload", batchItems, ciaos.BatchOptions{Concurrency: concurrency}); err != nil {
+		t.Fatalf("unexpected error from Batch: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxSeen != concurrency {
+		t.Errorf("expected exactly %d concurrent transfers, saw at most %d", concurrency, maxSeen)
+	}
+}
+
+// TestBatchTransfersActualData verifies that an "upload" BatchItem's
+// Data actually reaches the transfer server, and that a "download"
+// item's bytes come back on the result, rather than Batch fabricating
+// BytesTransferred from the caller-supplied Size.
+func TestBatchTransfersActualData(t *testing.T) {
+	const uploadPayload = "the quick brown fox"
+	const downloadPayload = "jumps over the lazy dog"
+
+	var receivedUpload []byte
+	var transferServer *httptest.Server
+	transferServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/up":
+			body, _ := io.ReadAll(r.Body)
+			receivedUpload = body
+			w.WriteHeader(http.StatusOK)
+		case "/down":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(downloadPayload))
+		}
+	}))
+	defer transferServer.Close()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{"key": "up", "action": map[string]any{"href": transferServer.URL + "/up"}},
+				{"key": "down", "action": map[string]any{"href": transferServer.URL + "/down"}},
+			},
+		})
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	uploadResults, err := cfg.Batch("upload", []ciaos.BatchItem{
+		{Key: "up", Size: int64(len(uploadPayload)), Data: []byte(uploadPayload)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error from upload Batch: %v", err)
+	}
+	if !bytes.Equal(receivedUpload, []byte(uploadPayload)) {
+		t.Errorf("transfer server received %q, want %q", receivedUpload, uploadPayload)
+	}
+	if uploadResults[0].BytesTransferred != int64(len(uploadPayload)) {
+		t.Errorf("expected BytesTransferred %d for upload, got %d", len(uploadPayload), uploadResults[0].BytesTransferred)
+	}
+
+	downloadResults, err := cfg.Batch("download", []ciaos.BatchItem{
+		{Key: "down", Size: int64(len(downloadPayload))},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error from download Batch: %v", err)
+	}
+	if !bytes.Equal(downloadResults[0].Data, []byte(downloadPayload)) {
+		t.Errorf("expected downloaded Data %q, got %q", downloadPayload, downloadResults[0].Data)
+	}
+	if downloadResults[0].BytesTransferred != int64(len(downloadPayload)) {
+		t.Errorf("expected BytesTransferred %d for download, got %d", len(downloadPayload), downloadResults[0].BytesTransferred)
+	}
+}
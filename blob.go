@@ -0,0 +1,282 @@
+package ciaos
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+
+	flatbufferHandler "github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// Blob is a sized, randomly-readable source of bytes that PutBlob
+// splits into chunks for concurrent upload.
+type Blob interface {
+	// ReadAt reads len(p) bytes from the blob starting at off, as io.ReaderAt.
+	ReadAt(p []byte, off int64) (int, error)
+	// Size returns the blob's total length in bytes.
+	Size() int64
+	// Close releases any resources backing the blob.
+	Close() error
+}
+
+// fileBlob adapts an *os.File to Blob.
+type fileBlob struct {
+	file *os.File
+	size int64
+}
+
+// NewFileBlob wraps an already-open file as a Blob. The file's current
+// size is captured immediately via Stat.
+func NewFileBlob(file *os.File) (Blob, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file blob: %v", err)
+	}
+	return &fileBlob{file: file, size: info.Size()}, nil
+}
+
+func (b *fileBlob) ReadAt(p []byte, off int64) (int, error) { return b.file.ReadAt(p, off) }
+func (b *fileBlob) Size() int64                             { return b.size }
+func (b *fileBlob) Close() error                            { return b.file.Close() }
+
+// byteBlob adapts an in-memory byte slice to Blob.
+type byteBlob struct {
+	data []byte
+}
+
+// NewByteBlob wraps data as a Blob backed entirely by memory.
+func NewByteBlob(data []byte) Blob {
+	return &byteBlob{data: data}
+}
+
+func (b *byteBlob) ReadAt(p []byte, off int64) (int, error) {
+	return bytes.NewReader(b.data).ReadAt(p, off)
+}
+func (b *byteBlob) Size() int64  { return int64(len(b.data)) }
+func (b *byteBlob) Close() error { return nil }
+
+// UploadOptions configures PutBlob.
+//
+// Parameters:
+//
+//	ChunkSize (int64): Size in bytes of each uploaded chunk. Defaults to DefaultBlobChunkSize.
+//	Concurrency (int): Maximum number of chunks uploaded at once. Defaults to DefaultBlobConcurrency.
+type UploadOptions struct {
+	ChunkSize   int64
+	Concurrency int
+}
+
+// DefaultBlobChunkSize is the chunk size PutBlob uses when
+// UploadOptions.ChunkSize is left at zero.
+const DefaultBlobChunkSize int64 = 32 * 1024 * 1024
+
+// DefaultBlobConcurrency is the number of concurrent chunk uploads
+// PutBlob uses when UploadOptions.Concurrency is left at zero.
+const DefaultBlobConcurrency = 4
+
+func (opts UploadOptions) chunkSize() int64 {
+	if opts.ChunkSize > 0 {
+		return opts.ChunkSize
+	}
+	return DefaultBlobChunkSize
+}
+
+func (opts UploadOptions) concurrency() int {
+	if opts.Concurrency > 0 {
+		return opts.Concurrency
+	}
+	return DefaultBlobConcurrency
+}
+
+// PutBlob uploads blob to key by splitting it into fixed-size chunks
+// and POSTing them concurrently to /put/{key}/chunk/{index}, then
+// sealing the upload with a final call to /put/{key}/commit. Each
+// chunk is wrapped in the same FlatBuffer envelope Put/PutBinary use,
+// so the server can reuse flatbufferHandler.ParseFlatBuffer on each
+// chunk it receives.
+//
+// Parameters:
+//
+//	ctx (context.Context): Governs cancellation of the whole upload.
+//	key (string): The key the completed blob will be stored under.
+//	blob (Blob): The data source to upload.
+//	opts (UploadOptions): Chunk size and concurrency settings.
+//
+// Returns:
+// (*http.Response): The response from the final commit request.
+// (error): An error if any chunk upload or the commit fails.
+func (config *Config) PutBlob(ctx context.Context, key string, blob Blob, opts UploadOptions) (*http.Response, error) {
+
+	chunkSize := opts.chunkSize()
+	totalSize := blob.Size()
+	chunkCount := int((totalSize + chunkSize - 1) / chunkSize)
+	if chunkCount == 0 {
+		chunkCount = 1
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(opts.concurrency())
+
+	for index := 0; index < chunkCount; index++ {
+		index := index
+		group.Go(func() error {
+			return config.putBlobChunk(groupCtx, key, blob, index, chunkCount, chunkSize, totalSize)
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return config.commitBlob(ctx, key)
+}
+
+// putBlobChunk reads and uploads a single chunk of blob.
+func (config *Config) putBlobChunk(ctx context.Context, key string, blob Blob, index, chunkCount int, chunkSize, totalSize int64) error {
+
+	offset := int64(index) * chunkSize
+	remaining := totalSize - offset
+	if remaining > chunkSize {
+		remaining = chunkSize
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	buf := make([]byte, remaining)
+	if remaining > 0 {
+		if _, err := blob.ReadAt(buf, offset); err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read chunk %d: %v", index, err)
+		}
+	}
+
+	flatBufferData, err := flatbufferHandler.CreateFlatBufferChunk(buf)
+	if err != nil {
+		return fmt.Errorf("failed to create chunk %d FlatBuffer: %v", index, err)
+	}
+
+	sum := sha256.Sum256(buf)
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		fmt.Sprintf("%s/put/%s/chunk/%d", config.APIURL, key, index), bytes.NewReader(flatBufferData))
+	if err != nil {
+		return fmt.Errorf("failed to create chunk %d request: %v", index, err)
+	}
+	req.Header.Set("User", config.UserId)
+	req.Header.Set("X-Ciaos-Chunk-Count", strconv.Itoa(chunkCount))
+	req.Header.Set("X-Ciaos-Chunk-Index", strconv.Itoa(index))
+	req.Header.Set("X-Ciaos-Chunk-SHA256", hex.EncodeToString(sum[:]))
+	req.Header.Set("X-Ciaos-Total-Size", strconv.FormatInt(totalSize, 10))
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error uploading chunk %d: %v", index, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return wrapAPIError(fmt.Sprintf("put_blob_chunk[%d]", index), key, resp.StatusCode, body, resp.Header.Get("Content-Type"))
+	}
+
+	return nil
+}
+
+// commitBlob seals a chunked upload, making it visible at key.
+func (config *Config) commitBlob(ctx context.Context, key string) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/put/%s/commit", config.APIURL, key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error committing blob: %v", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, wrapAPIError("commit_blob", key, resp.StatusCode, body, resp.Header.Get("Content-Type"))
+	}
+
+	return resp, nil
+}
+
+// GetBlob fetches key's chunks in parallel using HTTP Range requests
+// and writes each one to w at its corresponding offset.
+//
+// Parameters:
+//
+//	ctx (context.Context): Governs cancellation of the whole download.
+//	key (string): The key of the resource to download.
+//	w (io.WriterAt): Destination the chunks are written to at their offsets.
+//	opts (UploadOptions): Chunk size and concurrency settings (ChunkSize governs the Range size).
+//
+// Returns:
+// (error): An error if the object's size could not be determined, or any chunk download or write fails.
+func (config *Config) GetBlob(ctx context.Context, key string, w io.WriterAt, opts UploadOptions) error {
+
+	descriptor, err := config.Stat(key)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s before download: %v", key, err)
+	}
+
+	chunkSize := opts.chunkSize()
+	chunkCount := int((descriptor.Size + chunkSize - 1) / chunkSize)
+	if chunkCount == 0 {
+		return nil
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(opts.concurrency())
+
+	for index := 0; index < chunkCount; index++ {
+		index := index
+		group.Go(func() error {
+			return config.getBlobChunk(groupCtx, key, w, index, chunkSize, descriptor.Size)
+		})
+	}
+
+	return group.Wait()
+}
+
+// getBlobChunk downloads a single byte range of key and writes it to w
+// at the matching offset.
+func (config *Config) getBlobChunk(ctx context.Context, key string, w io.WriterAt, index int, chunkSize, totalSize int64) error {
+
+	offset := int64(index) * chunkSize
+	length := chunkSize
+	if offset+length > totalSize {
+		length = totalSize - offset
+	}
+
+	stream, err := config.GetStream(ctx, key, WithRange(offset, length))
+	if err != nil {
+		return fmt.Errorf("failed to open range for chunk %d: %v", index, err)
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		return fmt.Errorf("failed to read chunk %d: %v", index, err)
+	}
+
+	if _, err := w.WriteAt(data, offset); err != nil {
+		return fmt.Errorf("failed to write chunk %d: %v", index, err)
+	}
+
+	return nil
+}
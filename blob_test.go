@@ -0,0 +1,154 @@
+package ciaos_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+	"github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// TestPutBlobUploadsChunksAndCommits verifies that PutBlob splits a
+// blob into the expected number of chunks, uploads each one, and
+// finishes with a commit request.
+func TestPutBlobUploadsChunksAndCommits(t *testing.T) {
+	var mu sync.Mutex
+	chunksSeen := map[string]bool{}
+	committed := false
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/put/bigblob/commit":
+			committed = true
+		default:
+			chunksSeen[r.URL.Path] = true
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+	blob := ciaos.NewByteBlob(make([]byte, 25))
+
+	_, err := cfg.PutBlob(context.Background(), "bigblob", blob, ciaos.UploadOptions{ChunkSize: 10, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error from PutBlob: %v", err)
+	}
+
+	if len(chunksSeen) != 3 {
+		t.Fatalf("expected 3 chunk uploads for a 25 byte blob with chunk size 10, got %d", len(chunksSeen))
+	}
+	if !committed {
+		t.Fatalf("expected PutBlob to issue a final commit request")
+	}
+}
+
+// TestPutBlobEmptyBlob verifies that PutBlob uploads a single
+// zero-byte chunk and still commits for an empty blob, rather than
+// failing because there's no data to split.
+func TestPutBlobEmptyBlob(t *testing.T) {
+	var mu sync.Mutex
+	chunksSeen := map[string]bool{}
+	committed := false
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/put/emptyblob/commit":
+			committed = true
+		default:
+			chunksSeen[r.URL.Path] = true
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+	blob := ciaos.NewByteBlob(nil)
+
+	_, err := cfg.PutBlob(context.Background(), "emptyblob", blob, ciaos.UploadOptions{ChunkSize: 10, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error from PutBlob: %v", err)
+	}
+
+	if len(chunksSeen) != 1 {
+		t.Fatalf("expected exactly one zero-byte chunk upload for an empty blob, got %d", len(chunksSeen))
+	}
+	if !committed {
+		t.Fatalf("expected PutBlob to issue a final commit request")
+	}
+}
+
+// TestGetBlobWritesChunksAtOffset verifies that GetBlob fetches ranges
+// in parallel and writes each one back at the correct offset.
+func TestGetBlobWritesChunksAtOffset(t *testing.T) {
+	full := []byte("0123456789abcdefghij")
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", "20")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		start, end, err := parseTestRange(r.Header.Get("Range"))
+		if err != nil {
+			t.Fatalf("failed to parse range %q: %v", r.Header.Get("Range"), err)
+		}
+		if end >= len(full) {
+			end = len(full) - 1
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := handlers.WriteFramedChunk(w, full[start:end+1]); err != nil {
+			t.Fatalf("failed to write framed chunk: %v", err)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	dst := make(testWriterAt, 20)
+	err := cfg.GetBlob(context.Background(), "bigblob", &dst, ciaos.UploadOptions{ChunkSize: 8, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error from GetBlob: %v", err)
+	}
+
+	if string(dst) != string(full) {
+		t.Errorf("expected reassembled %q, got %q", string(full), string(dst))
+	}
+}
+
+type testWriterAt []byte
+
+func (w *testWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	n := copy((*w)[off:], p)
+	return n, nil
+}
+
+// parseTestRange parses a "bytes=start-end" Range header into its
+// inclusive start/end offsets.
+func parseTestRange(header string) (int, int, error) {
+	header = strings.TrimPrefix(header, "bytes=")
+	parts := strings.SplitN(header, "-", 2)
+
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
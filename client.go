@@ -2,6 +2,7 @@ package ciaos
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,7 +36,11 @@ func Ciaos(config Config) (*Config, error) {
 
 // Put uploads a file to a server, storing it with the given key.
 // If no key is provided, the base name of the filePath is used as
-// the key.
+// the key. Put is equivalent to PutCtx with context.Background().
+//
+// An empty file is uploaded as a genuine zero-byte object rather than
+// being treated as a no-op, the same as an empty dataList passed to
+// Update.
 
 // Note: Despite the name, this function uses the POST method,
 // not the PUT method.
@@ -49,6 +54,12 @@ func Ciaos(config Config) (*Config, error) {
 // (*http.Response): The HTTP response from the server.
 // (error): An error if the operation fails at any step.
 func (config *Config) Put(filePath string, key string) (*http.Response, error) {
+	return config.PutCtx(context.Background(), filePath, key)
+}
+
+// PutCtx is Put, but accepts a context.Context that is honored for
+// cancellation, deadlines, and retry backoff.
+func (config *Config) PutCtx(ctx context.Context, filePath string, key string) (*http.Response, error) {
 
 	if config.UserId == "" {
 		return nil, fmt.Errorf("user id must not be empty")
@@ -76,30 +87,33 @@ func (config *Config) Put(filePath string, key string) (*http.Response, error) {
 		return nil, fmt.Errorf("failed to read file: %v", err)
 	}
 
-	// Creates the FlatBuffer from the files data.
-	flatBufferData, err := flatbufferHandler.CreateFlatBuffer([][]byte{data})
+	// Creates the FlatBuffer from the files data, embedding a content
+	// digest so the server (and a later Get) can verify integrity.
+	flatBufferData, err := flatbufferHandler.CreateFlatBufferDigest([][]byte{data}, config.digestAlgorithm())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create FlatBuffer Data: %v", err)
 	}
 
 	// Creates an HTTP POST request to upload the data,
-	// adds the UserId in the header and execute the req.
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/put/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create PUT request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP PUT request failed: %v", err)
-	}
-
-	return resp, nil
+	// adds the UserId in the header and execute the req, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/put/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create PUT request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		if len(data) == 0 {
+			req.Header.Set("X-Ciaos-Truncate", "true")
+		}
+		return req, nil
+	})
+	return resp, wrapClientError("put", key, err)
 }
 
-// PutBinary uploads the binary data to the server with the specified key.
+// PutBinary uploads the binary data to the server with the specified
+// key. PutBinary is equivalent to PutBinaryCtx with context.Background().
 
 // Note: Despite the name, this function uses the POST method,
 // not the PUT method.
@@ -112,31 +126,38 @@ func (config *Config) Put(filePath string, key string) (*http.Response, error) {
 // (*http.Response): The HTTP response from the server.
 // (error): An error if the operation fails, including issues with FlatBuffer creation or the HTTP request.
 func (config *Config) PutBinary(key string, dataList [][]byte) (*http.Response, error) {
+	return config.PutBinaryCtx(context.Background(), key, dataList)
+}
 
-	// Converts the binary dataList into a FlatBuffer format.
-	flatBufferData, err := flatbufferHandler.CreateFlatBuffer(dataList)
+// PutBinaryCtx is PutBinary, but accepts a context.Context that is
+// honored for cancellation, deadlines, and retry backoff.
+func (config *Config) PutBinaryCtx(ctx context.Context, key string, dataList [][]byte) (*http.Response, error) {
+
+	// Converts the binary dataList into a FlatBuffer format, embedding a
+	// content digest per entry so a later Get can verify integrity.
+	flatBufferData, err := flatbufferHandler.CreateFlatBufferDigest(dataList, config.digestAlgorithm())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create FlatBuffer Data: %v", err)
 	}
 
 	// Creates an HTTP POST request to upload the FlatBuffer data,
-	// adds the UserId in the header and execute the req.
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/put/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create POST request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP POST request failed: %v", err)
-	}
-
-	return resp, nil
+	// adds the UserId in the header and execute the req, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/put/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create POST request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		return req, nil
+	})
+
+	return resp, wrapClientError("put_binary", key, err)
 }
 
-// UpdateKey updates the key of an existing resource.
+// UpdateKey updates the key of an existing resource. UpdateKey is
+// equivalent to UpdateKeyCtx with context.Background().
 
 // Parameters:
 //  oldKey (string): The current key of the resource to be updated.
@@ -146,19 +167,27 @@ func (config *Config) PutBinary(key string, dataList [][]byte) (*http.Response,
 // (string): The server's response body as a string, which may include confirmation or status details.
 // (error): An error if the operation fails, including issues with the HTTP request or response processing.
 func (config *Config) UpdateKey(oldKey string, newKey string) (string, error) {
+	return config.UpdateKeyCtx(context.Background(), oldKey, newKey)
+}
 
-	// Creates the POST request to update the key on the server.
-	// adds the UserId to the req headers and execute the req.
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/update_key/%s/%s", config.APIURL, oldKey, newKey), nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create POST request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// UpdateKeyCtx is UpdateKey, but accepts a context.Context that is
+// honored for cancellation and deadlines.
+func (config *Config) UpdateKeyCtx(ctx context.Context, oldKey string, newKey string) (string, error) {
+
+	// Creates the POST request to update the key on the server,
+	// adds the UserId to the req headers and execute the req, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/update_key/%s/%s", config.APIURL, oldKey, newKey), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create POST request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		return req, nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("HTTP error during key update: %v", err)
+		return "", wrapClientError("update_key", oldKey, err)
 	}
 	defer resp.Body.Close()
 
@@ -170,16 +199,32 @@ func (config *Config) UpdateKey(oldKey string, newKey string) (string, error) {
 	return string(body), nil
 }
 
-// Update updates an existing resource with new binary data.
+// Update updates an existing resource with new binary data. Update is
+// equivalent to UpdateCtx with context.Background().
+//
+// An empty dataList truncates the resource to zero bytes rather than
+// being treated as a no-op: the client sets the X-Ciaos-Truncate
+// header whenever dataList is empty or opts explicitly requests
+// Truncate, so the server can distinguish "no data submitted" from
+// "leave the existing object alone".
 
 // Parameters:
 // key (string): The key of the resource to be updated.
 // dataList ([][]byte): A list of byte slices containing the binary data to update the resource.
+// opts (...PutOptions): Optional conditional-write and truncation settings.
 
 // Returns:
 // (*http.Response): The HTTP response from the server, which may include status or confirmation.
 // (error): An error if the operation fails, including issues with FlatBuffer creation or the HTTP request.
-func (config *Config) Update(key string, dataList [][]byte) (*http.Response, error) {
+func (config *Config) Update(key string, dataList [][]byte, opts ...PutOptions) (*http.Response, error) {
+	return config.UpdateCtx(context.Background(), key, dataList, opts...)
+}
+
+// UpdateCtx is Update, but accepts a context.Context that is honored
+// for cancellation, deadlines, and retry backoff.
+func (config *Config) UpdateCtx(ctx context.Context, key string, dataList [][]byte, opts ...PutOptions) (*http.Response, error) {
+
+	options := mergePutOptions(opts)
 
 	// Converts the binary data list into a FlatBuffer format.
 	flatBufferData, err := flatbufferHandler.CreateFlatBuffer(dataList)
@@ -188,23 +233,25 @@ func (config *Config) Update(key string, dataList [][]byte) (*http.Response, err
 	}
 
 	// Creates an HTTP POST request to update the FlatBuffer data,
-	// adds the UserId in the header and execute the req.
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/update/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create PUT request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP error during update: %v", err)
-	}
-
-	return resp, nil
+	// adds the UserId in the header and execute the req, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/update/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create PUT request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		options.applyTruncate(req, len(dataList) == 0)
+		options.applyConditional(req)
+		return req, nil
+	})
+
+	return resp, wrapClientError("update", key, err)
 }
 
-// Append sends data to be appended to a key.
+// Append sends data to be appended to a key. Append is equivalent to
+// AppendCtx with context.Background().
 
 // Parameters:
 // key: string identifier where the data will be appended
@@ -214,6 +261,12 @@ func (config *Config) Update(key string, dataList [][]byte) (*http.Response, err
 // *http.Response: the server's response
 // error: any error encountered during the operation
 func (config *Config) Append(key string, dataList [][]byte) (*http.Response, error) {
+	return config.AppendCtx(context.Background(), key, dataList)
+}
+
+// AppendCtx is Append, but accepts a context.Context that is honored
+// for cancellation, deadlines, and retry backoff.
+func (config *Config) AppendCtx(ctx context.Context, key string, dataList [][]byte) (*http.Response, error) {
 
 	// Converts the binary dataList into a FlatBuffer format.
 	flatBufferData, err := flatbufferHandler.CreateFlatBuffer(dataList)
@@ -222,23 +275,23 @@ func (config *Config) Append(key string, dataList [][]byte) (*http.Response, err
 	}
 
 	// Creates an HTTP POST request to upload the FlatBuffer data,
-	// adds the UserId in the header and execute the req.
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/append/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create POST request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP error during append: %v", err)
-	}
-
-	return resp, nil
+	// adds the UserId in the header and execute the req, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/append/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create POST request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		return req, nil
+	})
+
+	return resp, wrapClientError("append", key, err)
 }
 
-// Delete removes the resource associated with the key
+// Delete removes the resource associated with the key. Delete is
+// equivalent to DeleteCtx with context.Background().
 
 // Parameters:
 // key (string): The key of the resource to be deleted.
@@ -247,25 +300,30 @@ func (config *Config) Append(key string, dataList [][]byte) (*http.Response, err
 // (*http.Response): The HTTP response from the server, which may include status or confirmation.
 // (error): An error if the operation fails, including issues with creating or executing the DELETE request.
 func (config *Config) Delete(key string) (*http.Response, error) {
+	return config.DeleteCtx(context.Background(), key)
+}
 
-	//Creates a DELETE request to remove the resource associated with the key.
-	// adds the UserId to the req headers and execute the req.
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/delete/%s", config.APIURL, key), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create DELETE request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP error during deletion: %v", err)
-	}
-
-	return resp, nil
+// DeleteCtx is Delete, but accepts a context.Context that is honored
+// for cancellation and deadlines.
+func (config *Config) DeleteCtx(ctx context.Context, key string) (*http.Response, error) {
+
+	//Creates a DELETE request to remove the resource associated with the key,
+	// adds the UserId to the req headers and execute the req, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/delete/%s", config.APIURL, key), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DELETE request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		return req, nil
+	})
+	return resp, wrapClientError("delete", key, err)
 }
 
 // Get retrieves binary data and parses it from a FlatBuffer format.
+// Get is equivalent to GetCtx with context.Background().
 
 // Parameters:
 //  key (string): The key of the resource to be retrieved.
@@ -275,19 +333,27 @@ func (config *Config) Delete(key string) (*http.Response, error) {
 // (error): An error if the operation fails, including issues with creating the request,
 // retrieving the response, or parsing the FlatBuffer data.
 func (config *Config) Get(key string) ([][]byte, error) {
+	return config.GetCtx(context.Background(), key)
+}
 
-	// Creates a GET request to retrieve the resource identified by the key.
-	// adds the UserId to req headers and execute the request.
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/get/%s", config.APIURL, key), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GET request: %v", err)
-	}
-	req.Header.Set("User", config.UserId)
+// GetCtx is Get, but accepts a context.Context that is honored for
+// cancellation, deadlines, and retry backoff.
+func (config *Config) GetCtx(ctx context.Context, key string) ([][]byte, error) {
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// Creates a GET request to retrieve the resource identified by the key.
+	// adds the UserId to req headers and execute the request, retrying
+	// non-fatal failures per config.RetryPolicy.
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/get/%s", config.APIURL, key), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GET request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("HTTP error during retrieval: %v", err)
+		return nil, wrapClientError("get", key, err)
 	}
 	defer resp.Body.Close()
 
@@ -296,10 +362,14 @@ func (config *Config) Get(key string) ([][]byte, error) {
 		return nil, fmt.Errorf("failed to read response body: %v", err)
 	}
 
-	// Parse the response body from a FlatBuffer format into a list of byte slices.
-	// Return the parsed binary data.
-	fileDataList, err := flatbufferHandler.ParseFlatBuffer(bodyBytes)
+	// Parse the response body from a FlatBuffer format into a list of
+	// byte slices, recomputing and verifying each entry's embedded
+	// content digest along the way.
+	fileDataList, err := flatbufferHandler.ParseFlatBufferVerified(bodyBytes, config.digestAlgorithm())
 	if err != nil {
+		if mismatch, ok := err.(*flatbufferHandler.DigestMismatchError); ok {
+			return fileDataList, &DigestMismatchError{Key: key, Expected: mismatch.Expected, Actual: mismatch.Actual}
+		}
 		return nil, fmt.Errorf("error parsing FlatBuffer: %v", err)
 	}
 	return fileDataList, nil
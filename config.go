@@ -0,0 +1,105 @@
+package ciaos
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	flatbufferHandler "github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// Config holds the connection and behaviour settings used by every
+// Ciaos client method.
+//
+// Parameters:
+//
+//	APIURL (string): Base URL of the Ciaos API, e.g. "https://ciaos.example.com".
+//	UserId (string): Identifier sent in the "User" request header.
+//	UserAccessKey (string): Access key associated with UserId, reserved
+//	                        for future authentication schemes.
+//	ChunkSize (int64): Size in bytes of each chunk written during a
+//	                    resumable upload (see StartUpload). Defaults to
+//	                    DefaultChunkSize when left at zero.
+//	RetryPolicy (RetryPolicy): Governs retries of non-fatal failures
+//	                           across Put, PutBinary, Update, and
+//	                           Append. Defaults to DefaultRetryPolicy
+//	                           when left at its zero value.
+//	DigestAlgorithm (DigestAlgorithm): Algorithm used to compute and
+//	                                   verify content digests on Put
+//	                                   and Get. Defaults to SHA256.
+//	HTTPClient (*http.Client): Client used to issue every request, so
+//	                           callers can configure timeouts, TLS, or
+//	                           proxies. Defaults to defaultHTTPClient,
+//	                           which pools and reuses connections.
+type Config struct {
+	APIURL          string
+	UserId          string
+	UserAccessKey   string
+	ChunkSize       int64
+	RetryPolicy     RetryPolicy
+	DigestAlgorithm DigestAlgorithm
+	HTTPClient      *http.Client
+
+	// batchUnsupported remembers that the server has previously
+	// responded to /batch with "not implemented", so later Batch calls
+	// skip straight to the legacy per-item fallback.
+	batchUnsupported bool
+}
+
+// defaultHTTPClient is used whenever Config.HTTPClient is left nil. Its
+// Transport tunes idle-connection reuse and dial timeouts instead of
+// relying on http.DefaultTransport, so repeated calls against the same
+// APIURL don't pay a fresh TCP/TLS handshake every time.
+var defaultHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   16,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+}
+
+func (config *Config) httpClient() *http.Client {
+	if config.HTTPClient != nil {
+		return config.HTTPClient
+	}
+	return defaultHTTPClient
+}
+
+// DefaultChunkSize is the chunk size used by resumable uploads when
+// Config.ChunkSize is not set.
+const DefaultChunkSize int64 = 8 * 1024 * 1024
+
+func (config *Config) chunkSize() int64 {
+	if config.ChunkSize > 0 {
+		return config.ChunkSize
+	}
+	return DefaultChunkSize
+}
+
+func (config *Config) digestAlgorithm() DigestAlgorithm {
+	if config.DigestAlgorithm != nil {
+		return config.DigestAlgorithm
+	}
+	return SHA256
+}
+
+// DigestAlgorithm computes and verifies content-addressable digests of
+// the data carried by a FileData entry. SHA256 is the default and
+// built-in implementation; register others with RegisterDigestAlgorithm.
+type DigestAlgorithm = flatbufferHandler.DigestAlgorithm
+
+// SHA256 is the default DigestAlgorithm.
+var SHA256 = flatbufferHandler.SHA256
+
+// RegisterDigestAlgorithm makes algo available to Get for verifying
+// objects that were stored with it, keyed by algo.Name().
+func RegisterDigestAlgorithm(algo DigestAlgorithm) {
+	flatbufferHandler.RegisterDigestAlgorithm(algo)
+}
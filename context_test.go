@@ -0,0 +1,57 @@
+package ciaos_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestGetCtxCancellation verifies that GetCtx aborts immediately when
+// its context is cancelled instead of waiting for the request to
+// complete.
+func TestGetCtxCancellation(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cfg.GetCtx(ctx, "testkey")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatalf("expected GetCtx to fail once its context deadline passed")
+	}
+	if elapsed > 150*time.Millisecond {
+		t.Fatalf("expected GetCtx to return promptly after cancellation, took %v", elapsed)
+	}
+}
+
+// TestPutBinaryDefaultsToBackgroundContext verifies the non-Ctx
+// PutBinary method still succeeds unchanged, i.e. it is a thin wrapper
+// around PutBinaryCtx.
+func TestPutBinaryDefaultsToBackgroundContext(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	resp, err := cfg.PutBinary("testkey", [][]byte{[]byte("data")})
+	if err != nil {
+		t.Fatalf("unexpected error from PutBinary: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,78 @@
+package ciaos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// DigestMismatchError is returned by Get when the digest recomputed
+// for key on receipt does not match the digest embedded by the sender,
+// indicating the object was corrupted in transit or at rest.
+type DigestMismatchError struct {
+	Key      string
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("ciaos: digest mismatch for key %q: expected %s, got %s", e.Key, e.Expected, e.Actual)
+}
+
+// Descriptor summarizes an object's metadata as reported by Stat,
+// mirroring the sort of content descriptor used by content-addressable
+// storage systems.
+//
+// Parameters:
+//
+//	Key (string): The object's key.
+//	Size (int64): The object's size in bytes.
+//	Digest (string): The object's content digest, in "<algorithm>:<hex>" form.
+//	MediaType (string): The object's media type, if the server reports one.
+type Descriptor struct {
+	Key       string
+	Size      int64
+	Digest    string
+	MediaType string
+}
+
+// Stat cheaply checks existence and integrity metadata for key via a
+// HEAD request, without downloading the object's contents.
+//
+// Parameters:
+//
+//	key (string): The key of the resource to describe.
+//
+// Returns:
+// (Descriptor): The object's size, digest, and media type.
+// (error): An error if the HEAD request fails or the resource does not exist.
+func (config *Config) Stat(key string) (Descriptor, error) {
+
+	req, err := http.NewRequest("HEAD", fmt.Sprintf("%s/get/%s", config.APIURL, key), nil)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf("failed to create HEAD request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf("HTTP error during stat: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return Descriptor{}, wrapAPIError("stat", key, resp.StatusCode, body, resp.Header.Get("Content-Type"))
+	}
+
+	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+
+	return Descriptor{
+		Key:       key,
+		Size:      size,
+		Digest:    resp.Header.Get("X-Ciaos-Digest"),
+		MediaType: resp.Header.Get("Content-Type"),
+	}, nil
+}
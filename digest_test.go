@@ -0,0 +1,113 @@
+package ciaos_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestPutBinaryThenGetVerifiesDigest verifies that data uploaded via
+// PutBinary carries a digest the mock server echoes back, and that Get
+// round-trips the data successfully when the digest matches.
+func TestPutBinaryThenGetVerifiesDigest(t *testing.T) {
+	var stored []byte
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			buf := make([]byte, r.ContentLength)
+			r.Body.Read(buf)
+			stored = buf
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			w.Write(stored)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	if _, err := cfg.PutBinary("testkey", [][]byte{[]byte("integrity checked data")}); err != nil {
+		t.Fatalf("unexpected error from PutBinary: %v", err)
+	}
+
+	result, err := cfg.Get("testkey")
+	if err != nil {
+		t.Fatalf("unexpected error from Get: %v", err)
+	}
+
+	if len(result) != 1 || string(result[0]) != "integrity checked data" {
+		t.Fatalf("unexpected round-tripped data: %v", result)
+	}
+}
+
+// TestGetDetectsDigestMismatch verifies that Get returns a
+// *ciaos.DigestMismatchError when the bytes returned by the server
+// don't match the digest embedded at upload time, rather than handing
+// back silently corrupted data.
+func TestGetDetectsDigestMismatch(t *testing.T) {
+	var stored []byte
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			buf := make([]byte, r.ContentLength)
+			r.Body.Read(buf)
+			stored = buf
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			// Flip a byte in the stored data without touching the
+			// digest the sender embedded, simulating corruption in
+			// transit or at rest.
+			corrupted := bytes.Replace(stored, []byte("checked"), []byte("CHECKED"), 1)
+			w.WriteHeader(http.StatusOK)
+			w.Write(corrupted)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	if _, err := cfg.PutBinary("testkey", [][]byte{[]byte("integrity checked data")}); err != nil {
+		t.Fatalf("unexpected error from PutBinary: %v", err)
+	}
+
+	_, err := cfg.Get("testkey")
+	if err == nil {
+		t.Fatal("expected Get to report a digest mismatch, got nil error")
+	}
+
+	if _, ok := err.(*ciaos.DigestMismatchError); !ok {
+		t.Fatalf("expected *ciaos.DigestMismatchError, got %T: %v", err, err)
+	}
+}
+
+// TestStatReturnsDescriptor verifies that Stat issues a HEAD request
+// and surfaces the object's size, digest, and media type.
+func TestStatReturnsDescriptor(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Fatalf("expected HEAD request, got %s", r.Method)
+		}
+		w.Header().Set("Content-Length", "42")
+		w.Header().Set("X-Ciaos-Digest", "sha256:abcd")
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	descriptor, err := cfg.Stat("testkey")
+	if err != nil {
+		t.Fatalf("unexpected error from Stat: %v", err)
+	}
+
+	if descriptor.Size != 42 || descriptor.Digest != "sha256:abcd" || descriptor.MediaType != "application/octet-stream" {
+		t.Errorf("unexpected descriptor: %+v", descriptor)
+	}
+}
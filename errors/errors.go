@@ -0,0 +1,95 @@
+// Package errors provides the structured error type the Ciaos client
+// decodes server failures into, along with classification helpers used
+// to drive retry behaviour.
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ciaosErrorContentType is the Content-Type the server uses for
+// structured error bodies.
+const ciaosErrorContentType = "application/vnd.ciaos+json"
+
+// ClientError is returned whenever the server responds with a status
+// of 400 or above. When the response Content-Type is application/vnd.ciaos+json
+// its body is decoded into Code/Message/RequestID/DocURL; otherwise
+// those fields are left empty and Message falls back to the raw body.
+//
+// Parameters:
+//
+//	StatusCode (int): The HTTP status code returned by the server.
+//	Code (string): Machine-readable error code, e.g. "not_found".
+//	Message (string): Human-readable description of the failure.
+//	RequestID (string): Server-assigned identifier for correlating logs.
+//	DocURL (string): Link to documentation about this error, if any.
+type ClientError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	RequestID  string `json:"request_id"`
+	DocURL     string `json:"doc_url"`
+}
+
+func (e *ClientError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("ciaos: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("ciaos: %d: %s", e.StatusCode, e.Message)
+}
+
+// Decode builds a *ClientError from a response status code, body, and
+// Content-Type header. When the Content-Type matches the structured
+// ciaos error format the body is JSON-decoded; otherwise the raw body
+// is used verbatim as the message.
+func Decode(statusCode int, body []byte, contentType string) *ClientError {
+
+	clientErr := &ClientError{StatusCode: statusCode}
+
+	if contentType == ciaosErrorContentType {
+		if err := json.Unmarshal(body, clientErr); err == nil {
+			clientErr.StatusCode = statusCode
+			return clientErr
+		}
+	}
+
+	clientErr.Message = string(body)
+	return clientErr
+}
+
+// IsFatal reports whether err represents a failure that should NOT be
+// retried. HTTP 4xx responses (other than 429 Too Many Requests) are
+// fatal; 5xx responses, 429, and network-level timeouts are treated as
+// transient and therefore non-fatal.
+func IsFatal(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var clientErr *ClientError
+	if ce, ok := err.(*ClientError); ok {
+		clientErr = ce
+	}
+
+	if clientErr != nil {
+		switch {
+		case clientErr.StatusCode == http.StatusTooManyRequests:
+			return false
+		case clientErr.StatusCode >= 500:
+			return false
+		case clientErr.StatusCode >= 400:
+			return true
+		default:
+			return false
+		}
+	}
+
+	if netErr, ok := err.(net.Error); ok {
+		return !netErr.Timeout()
+	}
+
+	return true
+}
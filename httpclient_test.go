@@ -0,0 +1,85 @@
+package ciaos_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestCustomHTTPClientIsUsed verifies that requests go through a
+// caller-supplied Config.HTTPClient rather than the package default.
+func TestCustomHTTPClientIsUsed(t *testing.T) {
+	var used bool
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	cfg := &ciaos.Config{
+		APIURL:     mockServer.URL,
+		UserId:     "testuser",
+		HTTPClient: &http.Client{Transport: transport},
+	}
+
+	resp, err := cfg.PutBinary("testkey", [][]byte{[]byte("data")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if !used {
+		t.Error("expected the custom HTTPClient's Transport to be used")
+	}
+}
+
+// TestRetryPolicyBackoffRespectsMaxBackoff verifies that backoff growth
+// is capped at MaxBackoff regardless of how many attempts have elapsed.
+func TestRetryPolicyBackoffRespectsMaxBackoff(t *testing.T) {
+	attempts := 0
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{
+		APIURL: mockServer.URL,
+		UserId: "testuser",
+		RetryPolicy: ciaos.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+			Multiplier:     10,
+		},
+	}
+
+	start := time.Now()
+	_, err := cfg.PutBinary("testkey", [][]byte{[]byte("data")})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected Put to fail after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+	if elapsed > 100*time.Millisecond {
+		t.Errorf("expected backoff to stay capped near MaxBackoff, took %s", elapsed)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,54 @@
+package ciaos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PutStream uploads the contents of r to key by streaming it directly
+// as the request body, instead of buffering the whole payload with
+// io.ReadAll and wrapping it in a FlatBuffer the way Put/PutBinary do.
+// This is the right choice for piping os.Stdin, an HTTP proxy, or a
+// transcoder's output straight to the server without ever holding the
+// full object in memory.
+//
+// Parameters:
+//
+//	ctx (context.Context): Governs cancellation and deadlines for the request.
+//	key (string): The key under which the data will be stored.
+//	r (io.Reader): The data to upload.
+//	size (int64): The number of bytes r will yield. When size >= 0,
+//	               Content-Length is set explicitly; pass -1 if the
+//	               size is unknown and chunked transfer encoding should
+//	               be used instead.
+//
+// Returns:
+// (*http.Response): The HTTP response from the server.
+// (error): An error if the request could not be created or sent.
+func (config *Config) PutStream(ctx context.Context, key string, r io.Reader, size int64) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/put/%s", config.APIURL, key), r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create streaming PUT request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+	if size >= 0 {
+		req.ContentLength = size
+	}
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error during streaming upload: %v", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, wrapAPIError("put_stream", key, resp.StatusCode, body, resp.Header.Get("Content-Type"))
+	}
+
+	return resp, nil
+}
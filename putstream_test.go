@@ -0,0 +1,48 @@
+package ciaos_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestPutStreamSendsRawBodyWithContentLength verifies that PutStream
+// streams the reader directly as the request body and sets
+// Content-Length from the provided size, without wrapping the payload
+// in a FlatBuffer.
+func TestPutStreamSendsRawBodyWithContentLength(t *testing.T) {
+	var gotBody []byte
+	var gotContentLength int64
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentLength = r.ContentLength
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+		gotBody = body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+	payload := []byte("raw streamed payload")
+
+	resp, err := cfg.PutStream(context.Background(), "testkey", bytes.NewReader(payload), int64(len(payload)))
+	if err != nil {
+		t.Fatalf("unexpected error from PutStream: %v", err)
+	}
+	resp.Body.Close()
+
+	if string(gotBody) != string(payload) {
+		t.Errorf("expected raw body %q, got %q", payload, gotBody)
+	}
+	if gotContentLength != int64(len(payload)) {
+		t.Errorf("expected Content-Length %d, got %d", len(payload), gotContentLength)
+	}
+}
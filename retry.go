@@ -0,0 +1,147 @@
+package ciaos
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	ciaosErrors "github.com/vitality-ai/go-sdk/errors"
+)
+
+// RetryPolicy controls how many times, and with what backoff, a Ciaos
+// client method retries a request after a non-fatal failure (see
+// ciaosErrors.IsFatal). The zero value is replaced with
+// DefaultRetryPolicy.
+//
+// Parameters:
+//
+//	MaxAttempts (int): Total number of attempts, including the first. 1 disables retries.
+//	InitialBackoff (time.Duration): Delay before the first retry.
+//	MaxBackoff (time.Duration): Upper bound the backoff is capped at, regardless of Multiplier.
+//	Multiplier (float64): Factor the backoff is multiplied by after each attempt. 2 doubles it.
+//	Jitter (bool): When true, randomizes each backoff within [0, backoff) to avoid thundering herds.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         bool
+}
+
+// DefaultRetryPolicy is used whenever Config.RetryPolicy is left at
+// its zero value.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         true,
+}
+
+func (config *Config) retryPolicy() RetryPolicy {
+	if config.RetryPolicy.MaxAttempts == 0 {
+		return DefaultRetryPolicy
+	}
+	return config.RetryPolicy
+}
+
+func (policy RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	wait := float64(policy.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		wait *= multiplier
+	}
+	if policy.MaxBackoff > 0 && wait > float64(policy.MaxBackoff) {
+		wait = float64(policy.MaxBackoff)
+	}
+
+	backoff := time.Duration(wait)
+	if policy.Jitter && backoff > 0 {
+		backoff = time.Duration(rand.Int63n(int64(backoff)))
+	}
+	return backoff
+}
+
+// doWithRetry executes requests built by newReq, retrying non-fatal
+// failures (5xx, 429, network timeouts) up to policy.MaxAttempts times
+// with the policy's backoff. On return, the response body (if any) has
+// already been drained and is safe to read again from resp.Body. ctx
+// is checked between attempts so a cancellation aborts immediately
+// instead of waiting out the remaining backoff.
+func doWithRetry(ctx context.Context, client *http.Client, policy RetryPolicy, newReq func() (*http.Request, error)) (*http.Response, error) {
+
+	var lastResp *http.Response
+	var lastErr error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			lastResp = nil
+			if attempt < policy.MaxAttempts && !ciaosErrors.IsFatal(err) {
+				if sleepErr := sleepWithContext(ctx, policy.backoff(attempt)); sleepErr != nil {
+					return nil, sleepErr
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		if resp.StatusCode < 400 {
+			return resp, nil
+		}
+
+		clientErr := ciaosErrors.Decode(resp.StatusCode, body, resp.Header.Get("Content-Type"))
+		lastResp, lastErr = resp, clientErr
+
+		if ciaosErrors.IsFatal(clientErr) || attempt == policy.MaxAttempts {
+			return resp, clientErr
+		}
+
+		wait := policy.backoff(attempt)
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := time.ParseDuration(retryAfter + "s"); err == nil {
+				wait = seconds
+			}
+		}
+
+		if sleepErr := sleepWithContext(ctx, wait); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+
+	return lastResp, lastErr
+}
+
+// sleepWithContext waits for d, returning early with ctx.Err() if ctx
+// is cancelled first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package ciaos_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestPutRetriesNonFatalFailures verifies that a mock server returning
+// 503 on the first two attempts and 200 on the third still results in
+// a successful Put.
+func TestPutRetriesNonFatalFailures(t *testing.T) {
+	attempts := 0
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Data uploaded successfully"))
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	resp, err := cfg.PutBinary("testkey", [][]byte{[]byte("data")})
+	if err != nil {
+		t.Fatalf("expected Put to eventually succeed, got: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestPutFailsImmediatelyOnFatalError verifies that a 400 response is
+// not retried and surfaces immediately.
+func TestPutFailsImmediatelyOnFatalError(t *testing.T) {
+	attempts := 0
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("bad request"))
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	_, err := cfg.PutBinary("testkey", [][]byte{[]byte("data")})
+	if err == nil {
+		t.Fatalf("expected Put to fail on a 400 response")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt for a fatal error, got %d", attempts)
+	}
+}
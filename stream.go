@@ -0,0 +1,131 @@
+package ciaos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	flatbufferHandler "github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// GetOptions holds the settings applied by GetOption functions.
+type GetOptions struct {
+	rangeSet bool
+	offset   int64
+	length   int64
+}
+
+// GetOption customizes a GetStream call.
+type GetOption func(*GetOptions)
+
+// WithRange restricts a GetStream call to length bytes starting at
+// offset, translated into a "Range: bytes=offset-end" request header.
+// A length of 0 requests everything from offset to the end of the
+// object.
+func WithRange(offset, length int64) GetOption {
+	return func(o *GetOptions) {
+		o.rangeSet = true
+		o.offset = offset
+		o.length = length
+	}
+}
+
+// StreamReader is an io.ReadCloser returned by GetStream. It lazily
+// decodes length-prefixed FlatBuffer FileData frames from the
+// underlying response body as Read is called, so a large object never
+// needs to be materialized in memory up front. Size reports the
+// negotiated content length the server returned, or -1 if unknown.
+type StreamReader struct {
+	Size int64
+
+	body    io.ReadCloser
+	frames  *flatbufferHandler.FlatBufferReader
+	pending []byte
+	done    bool
+}
+
+// Read implements io.Reader, pulling the next FlatBuffer frame off the
+// wire whenever the previously decoded chunk has been fully consumed.
+func (sr *StreamReader) Read(p []byte) (int, error) {
+	for len(sr.pending) == 0 {
+		if sr.done {
+			return 0, io.EOF
+		}
+
+		chunk, err := sr.frames.Next()
+		if err == io.EOF {
+			sr.done = true
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		sr.pending = chunk
+	}
+
+	n := copy(p, sr.pending)
+	sr.pending = sr.pending[n:]
+	return n, nil
+}
+
+// Close releases the underlying HTTP response body.
+func (sr *StreamReader) Close() error {
+	return sr.body.Close()
+}
+
+// GetStream issues a GET for key and returns a reader that
+// incrementally decodes the response body's framed FlatBuffer chunks,
+// so large objects can be streamed to a caller without first loading
+// them entirely into memory. WithRange restricts the request to a
+// byte range via the Range header.
+//
+// Parameters:
+//
+//	ctx (context.Context): Governs cancellation and deadlines for the request.
+//	key (string): The key of the resource to retrieve.
+//	opts (...GetOption): Optional request modifiers, e.g. WithRange.
+//
+// Returns:
+// (io.ReadCloser): A StreamReader over the object's decoded bytes; Close it when done.
+// (error): An error if the request could not be created or sent.
+func (config *Config) GetStream(ctx context.Context, key string, opts ...GetOption) (io.ReadCloser, error) {
+
+	var options GetOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/get/%s", config.APIURL, key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GET request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+
+	if options.rangeSet {
+		end := ""
+		if options.length > 0 {
+			end = strconv.FormatInt(options.offset+options.length-1, 10)
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%s", options.offset, end))
+	}
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error during streaming retrieval: %v", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, wrapAPIError("get_stream", key, resp.StatusCode, body, resp.Header.Get("Content-Type"))
+	}
+
+	return &StreamReader{
+		Size:   resp.ContentLength,
+		body:   resp.Body,
+		frames: flatbufferHandler.NewFlatBufferReader(resp.Body),
+	}, nil
+}
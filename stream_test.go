@@ -0,0 +1,133 @@
+package ciaos_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+	"github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// TestGetStreamPartialReads verifies that GetStream can be read in
+// small increments and still reassembles the full object across
+// multiple framed chunks.
+func TestGetStreamPartialReads(t *testing.T) {
+	chunks := [][]byte{[]byte("hello "), []byte("streaming "), []byte("world")}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, chunk := range chunks {
+			if err := handlers.WriteFramedChunk(w, chunk); err != nil {
+				t.Fatalf("failed to write framed chunk: %v", err)
+			}
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	reader, err := cfg.GetStream(context.Background(), "bigkey")
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer reader.Close()
+
+	buf := make([]byte, 4)
+	var got []byte
+	for {
+		n, err := reader.Read(buf)
+		got = append(got, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected read error: %v", err)
+		}
+	}
+
+	expected := "hello streaming world"
+	if string(got) != expected {
+		t.Errorf("expected %q, got %q", expected, string(got))
+	}
+}
+
+// TestGetStreamRangeRequest verifies that WithRange is translated into
+// the correct Range request header.
+func TestGetStreamRangeRequest(t *testing.T) {
+	var gotRange string
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		handlers.WriteFramedChunk(w, []byte("partial"))
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	reader, err := cfg.GetStream(context.Background(), "bigkey", ciaos.WithRange(10, 20))
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer reader.Close()
+
+	if gotRange != "bytes=10-29" {
+		t.Errorf("expected Range header 'bytes=10-29', got %q", gotRange)
+	}
+}
+
+// TestGetStreamEmptyObject verifies that GetStream on a zero-byte
+// object returns an empty read rather than failing, since a
+// zero-length FileData entry is a legitimate empty payload, not a
+// missing field.
+func TestGetStreamEmptyObject(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := handlers.WriteFramedChunk(w, []byte{}); err != nil {
+			t.Fatalf("failed to write framed chunk: %v", err)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	reader, err := cfg.GetStream(context.Background(), "emptykey")
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer reader.Close()
+
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected zero bytes from an empty object, got %d", len(got))
+	}
+}
+
+// TestGetStreamEarlyClose verifies that closing the stream before it
+// is fully drained does not error.
+func TestGetStreamEarlyClose(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlers.WriteFramedChunk(w, []byte("first"))
+		handlers.WriteFramedChunk(w, []byte("second"))
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	reader, err := cfg.GetStream(context.Background(), "bigkey")
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+
+	buf := make([]byte, 2)
+	if _, err := reader.Read(buf); err != nil {
+		t.Fatalf("unexpected error on first read: %v", err)
+	}
+
+	if err := reader.Close(); err != nil {
+		t.Errorf("expected Close before full drain to succeed, got: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package ciaos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	flatbufferHandler "github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// PutOptions expresses conditional-write and truncation semantics for
+// Update (and, in future, Put).
+//
+// Parameters:
+//
+//	IfMatch (string): When set, the write only succeeds if the resource's current ETag matches.
+//	IfNoneMatch (string): When set to "*", the write only succeeds if the resource does not already exist.
+//	Truncate (bool): When true, forces the X-Ciaos-Truncate header even
+//	                 if dataList is non-empty, so a shorter payload
+//	                 replaces rather than appends to the existing bytes.
+type PutOptions struct {
+	IfMatch     string
+	IfNoneMatch string
+	Truncate    bool
+}
+
+func mergePutOptions(opts []PutOptions) PutOptions {
+	if len(opts) == 0 {
+		return PutOptions{}
+	}
+	return opts[0]
+}
+
+// applyTruncate sets the X-Ciaos-Truncate header when either the
+// caller explicitly requested it or the payload itself is empty, since
+// an empty dataList must truncate the object rather than being
+// silently treated as a no-op by the server.
+func (options PutOptions) applyTruncate(req *http.Request, emptyPayload bool) {
+	if options.Truncate || emptyPayload {
+		req.Header.Set("X-Ciaos-Truncate", "true")
+	}
+}
+
+func (options PutOptions) applyConditional(req *http.Request) {
+	if options.IfMatch != "" {
+		req.Header.Set("If-Match", options.IfMatch)
+	}
+	if options.IfNoneMatch != "" {
+		req.Header.Set("If-None-Match", options.IfNoneMatch)
+	}
+}
+
+// Truncate replaces the resource stored at key with a zero-byte
+// object. It is equivalent to Update(key, nil) but makes the intent
+// explicit and does not require the caller to construct an empty
+// dataList themselves. Truncate is equivalent to TruncateCtx with
+// context.Background().
+//
+// Parameters:
+//
+//	key (string): The key of the resource to truncate.
+//
+// Returns:
+// (*http.Response): The HTTP response from the server.
+// (error): An error if the operation fails, including issues with FlatBuffer creation or the HTTP request.
+func (config *Config) Truncate(key string) (*http.Response, error) {
+	return config.TruncateCtx(context.Background(), key)
+}
+
+// TruncateCtx is Truncate, but accepts a context.Context that is
+// honored for cancellation, deadlines, and retry backoff.
+func (config *Config) TruncateCtx(ctx context.Context, key string) (*http.Response, error) {
+
+	flatBufferData, err := flatbufferHandler.CreateFlatBuffer([][]byte{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create FlatBuffer Data: %v", err)
+	}
+
+	client := config.httpClient()
+	resp, err := doWithRetry(ctx, client, config.retryPolicy(), func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/update/%s", config.APIURL, key), bytes.NewReader(flatBufferData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create POST request: %v", err)
+		}
+		req.Header.Set("User", config.UserId)
+		req.Header.Set("X-Ciaos-Truncate", "true")
+		return req, nil
+	})
+
+	return resp, wrapClientError("truncate", key, err)
+}
@@ -0,0 +1,150 @@
+package ciaos_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+	"github.com/vitality-ai/go-sdk/utils/handlers"
+)
+
+// TestUpdateEmptyDataListTruncates verifies that overwriting an object
+// with an empty dataList sends the X-Ciaos-Truncate header and a
+// zero-byte payload, rather than silently preserving the old bytes.
+func TestUpdateEmptyDataListTruncates(t *testing.T) {
+	var gotTruncateHeader string
+	var gotBodyLen int
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTruncateHeader = r.Header.Get("X-Ciaos-Truncate")
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+
+		fileDataList, err := handlers.ParseFlatBuffer(body)
+		if err != nil {
+			t.Fatalf("failed to parse FlatBuffer body: %v", err)
+		}
+		for _, f := range fileDataList {
+			gotBodyLen += len(f)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	resp, err := cfg.Update("testkey", [][]byte{})
+	if err != nil {
+		t.Fatalf("unexpected error from Update: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotTruncateHeader != "true" {
+		t.Errorf("expected X-Ciaos-Truncate header to be set, got %q", gotTruncateHeader)
+	}
+	if gotBodyLen != 0 {
+		t.Errorf("expected a zero-byte object after truncation, got %d bytes", gotBodyLen)
+	}
+}
+
+// TestTruncateMethod verifies that Truncate issues a zero-byte Update
+// with the truncation header set.
+func TestTruncateMethod(t *testing.T) {
+	var gotTruncateHeader string
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTruncateHeader = r.Header.Get("X-Ciaos-Truncate")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	resp, err := cfg.Truncate("testkey")
+	if err != nil {
+		t.Fatalf("unexpected error from Truncate: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotTruncateHeader != "true" {
+		t.Errorf("expected X-Ciaos-Truncate header to be set, got %q", gotTruncateHeader)
+	}
+}
+
+// TestTruncateThenGetReturnsZeroBytes is an integration-style test
+// that writes a large object, truncates it, and verifies a subsequent
+// Get returns zero bytes rather than the stale contents — the class of
+// "overwriting with an empty file leaves old data" bug this method
+// exists to prevent.
+func TestTruncateThenGetReturnsZeroBytes(t *testing.T) {
+	var stored []byte
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("failed to read request body: %v", err)
+			}
+
+			fileDataList, err := handlers.ParseFlatBuffer(body)
+			if err != nil {
+				t.Fatalf("failed to parse FlatBuffer body: %v", err)
+			}
+			var bodyLen int
+			for _, f := range fileDataList {
+				bodyLen += len(f)
+			}
+
+			// A real server only treats an empty write as a truncation
+			// when the client signals it explicitly; otherwise an empty
+			// body is a no-op and the previously stored bytes survive.
+			// This is what makes the test actually exercise applyTruncate
+			// instead of passing regardless of whether it ran.
+			if bodyLen == 0 && r.Header.Get("X-Ciaos-Truncate") != "true" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			stored = body
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			w.Write(stored)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	large := make([]byte, 1<<20)
+	resp, err := cfg.PutBinary("bigkey", [][]byte{large})
+	if err != nil {
+		t.Fatalf("unexpected error from PutBinary: %v", err)
+	}
+	resp.Body.Close()
+
+	resp, err = cfg.Truncate("bigkey")
+	if err != nil {
+		t.Fatalf("unexpected error from Truncate: %v", err)
+	}
+	resp.Body.Close()
+
+	dataList, err := cfg.Get("bigkey")
+	if err != nil {
+		t.Fatalf("unexpected error from Get: %v", err)
+	}
+
+	var total int
+	for _, d := range dataList {
+		total += len(d)
+	}
+	if total != 0 {
+		t.Errorf("expected zero bytes after Truncate, got %d", total)
+	}
+}
@@ -0,0 +1,286 @@
+package ciaos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UploadState captures everything needed to resume an in-flight
+// resumable upload after a process restart or a network failure.
+//
+// Parameters:
+//
+//	UUID (string): Server-issued identifier for the upload session.
+//	Location (string): URL the client PATCHes successive chunks to.
+//	Offset (int64): Number of bytes the server has acknowledged so far.
+//	StartedAt (time.Time): When the upload session was created.
+type UploadState struct {
+	UUID      string
+	Location  string
+	Offset    int64
+	StartedAt time.Time
+}
+
+// Upload is a handle to a resumable, chunked upload started with
+// StartUpload. Successive calls to Write or ReadFrom PATCH the next
+// chunk to the server and advance State.Offset from the Range header
+// the server returns, so the handle can be persisted and resumed with
+// ResumeUpload after a failure.
+type Upload struct {
+	config *Config
+	Key    string
+	State  UploadState
+}
+
+// StartUpload begins a resumable upload session for key and returns a
+// handle that Write/ReadFrom append chunks to.
+//
+// Parameters:
+//
+//	key (string): The key the completed upload will be stored under.
+//
+// Returns:
+// (*Upload): A handle tracking the new upload session.
+// (error): An error if the session could not be created.
+func (config *Config) StartUpload(key string) (*Upload, error) {
+
+	if config.UserId == "" {
+		return nil, fmt.Errorf("user id must not be empty")
+	}
+
+	if config.APIURL == "" {
+		return nil, fmt.Errorf("api url must not be empty")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/uploads/%s", config.APIURL, key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload session request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error while starting upload: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status starting upload: %d", resp.StatusCode)
+	}
+
+	return &Upload{
+		config: config,
+		Key:    key,
+		State: UploadState{
+			UUID:      resp.Header.Get("Upload-UUID"),
+			Location:  resolveLocation(config.APIURL, resp.Header.Get("Location")),
+			Offset:    0,
+			StartedAt: time.Now(),
+		},
+	}, nil
+}
+
+// ResumeUpload rebuilds an Upload handle from a previously persisted
+// UploadState, re-querying the server for the offset it last
+// acknowledged so the caller can continue writing from that point even
+// if the in-memory state is stale.
+//
+// Parameters:
+//
+//	config (*Config): The client configuration to resume the upload under.
+//	key (string): The key the upload was started for.
+//	state (UploadState): The state persisted from a prior Upload handle.
+//
+// Returns:
+// (*Upload): A handle resuming the upload at the server-acknowledged offset.
+// (error): An error if the session's current offset could not be determined.
+func (config *Config) ResumeUpload(key string, state UploadState) (*Upload, error) {
+
+	if state.Location == "" {
+		return nil, fmt.Errorf("upload state is missing a location to resume from")
+	}
+
+	req, err := http.NewRequest("HEAD", state.Location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resume request: %v", err)
+	}
+	req.Header.Set("User", config.UserId)
+
+	client := config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error while resuming upload: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, fmt.Errorf("unexpected status resuming upload: %d", resp.StatusCode)
+	}
+
+	offset, err := parseRangeEnd(resp.Header.Get("Range"))
+	if err != nil {
+		offset = state.Offset
+	}
+
+	state.Offset = offset
+	return &Upload{config: config, Key: key, State: state}, nil
+}
+
+// Write PATCHes p to the server as the next chunk of the upload and
+// advances State.Offset from the response's Location/Range headers. It
+// satisfies io.Writer so an Upload can be used as the destination of
+// an io.Copy.
+func (u *Upload) Write(p []byte) (int, error) {
+
+	req, err := http.NewRequest("PATCH", u.State.Location, strings.NewReader(string(p)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create chunk request: %v", err)
+	}
+	req.Header.Set("User", u.config.UserId)
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/*", u.State.Offset, u.State.Offset+int64(len(p))-1))
+
+	client := u.config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("HTTP error while uploading chunk: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return 0, fmt.Errorf("unexpected status uploading chunk: %d", resp.StatusCode)
+	}
+
+	if loc := resp.Header.Get("Location"); loc != "" {
+		u.State.Location = resolveLocation(u.config.APIURL, loc)
+	}
+
+	if offset, err := parseRangeEnd(resp.Header.Get("Range")); err == nil {
+		u.State.Offset = offset
+	} else {
+		u.State.Offset += int64(len(p))
+	}
+
+	return len(p), nil
+}
+
+// ReadFrom reads r in Config.ChunkSize pieces, PATCHing each one to the
+// server in turn, and returns the total number of bytes uploaded. It
+// satisfies io.ReaderFrom.
+func (u *Upload) ReadFrom(r io.Reader) (int64, error) {
+
+	buf := make([]byte, u.config.chunkSize())
+	var total int64
+
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			if _, err := u.Write(buf[:n]); err != nil {
+				return total, err
+			}
+			total += int64(n)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return total, fmt.Errorf("failed to read upload source: %v", readErr)
+		}
+	}
+
+	return total, nil
+}
+
+// Commit finalizes the upload, making the uploaded chunks visible at
+// Key.
+//
+// Returns:
+// (*http.Response): The HTTP response from the server.
+// (error): An error if the finalization request fails.
+func (u *Upload) Commit() (*http.Response, error) {
+
+	req, err := http.NewRequest("PUT", u.State.Location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit request: %v", err)
+	}
+	req.Header.Set("User", u.config.UserId)
+
+	client := u.config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error while committing upload: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status committing upload: %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// Cancel aborts the upload session and discards any chunks the server
+// has buffered so far.
+func (u *Upload) Cancel() error {
+
+	req, err := http.NewRequest("DELETE", u.State.Location, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create cancel request: %v", err)
+	}
+	req.Header.Set("User", u.config.UserId)
+
+	client := u.config.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error while cancelling upload: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status cancelling upload: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// resolveLocation turns a Location header value into an absolute URL,
+// since servers are allowed to return either an absolute URL or a path
+// relative to apiURL.
+func resolveLocation(apiURL, location string) string {
+	if location == "" {
+		return ""
+	}
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return location
+	}
+	return apiURL + location
+}
+
+// parseRangeEnd extracts the inclusive end offset from a "bytes=0-1023"
+// or "bytes 0-1023/*" style Range header and returns it as the number
+// of bytes acknowledged (end + 1).
+func parseRangeEnd(header string) (int64, error) {
+	if header == "" {
+		return 0, fmt.Errorf("empty range header")
+	}
+
+	header = strings.TrimPrefix(header, "bytes=")
+	header = strings.TrimPrefix(header, "bytes ")
+
+	parts := strings.SplitN(header, "/", 2)
+	bounds := strings.SplitN(parts[0], "-", 2)
+	if len(bounds) != 2 {
+		return 0, fmt.Errorf("malformed range header: %s", header)
+	}
+
+	end, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed range end in header %q: %v", header, err)
+	}
+
+	return end + 1, nil
+}
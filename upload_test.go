@@ -0,0 +1,115 @@
+package ciaos_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ciaos "github.com/vitality-ai/go-sdk"
+)
+
+// TestResumableUploadAcrossFailure simulates a mid-stream failure on
+// the second chunk and verifies that resuming the upload continues
+// from the offset the server last acknowledged instead of restarting.
+func TestResumableUploadAcrossFailure(t *testing.T) {
+	key := "bigfile.bin"
+	var receivedOffset int64
+	failSecondChunk := true
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/uploads/"+key+"/session1")
+			w.Header().Set("Upload-UUID", "session1")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPatch:
+			if failSecondChunk && receivedOffset > 0 {
+				failSecondChunk = false
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			receivedOffset += 4
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", receivedOffset-1))
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodHead:
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", receivedOffset-1))
+			w.WriteHeader(http.StatusOK)
+		case http.MethodPut:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	upload, err := cfg.StartUpload(key)
+	if err != nil {
+		t.Fatalf("failed to start upload: %v", err)
+	}
+
+	if _, err := upload.Write([]byte("aaaa")); err != nil {
+		t.Fatalf("unexpected error writing first chunk: %v", err)
+	}
+
+	if _, err := upload.Write([]byte("bbbb")); err == nil {
+		t.Fatalf("expected the simulated mid-stream failure to surface an error")
+	}
+
+	resumed, err := cfg.ResumeUpload(key, upload.State)
+	if err != nil {
+		t.Fatalf("failed to resume upload: %v", err)
+	}
+
+	if resumed.State.Offset != 4 {
+		t.Fatalf("expected resumed upload to pick up at offset 4, got %d", resumed.State.Offset)
+	}
+
+	if _, err := resumed.Write([]byte("bbbb")); err != nil {
+		t.Fatalf("unexpected error resuming chunk upload: %v", err)
+	}
+
+	if resumed.State.Offset != 8 {
+		t.Fatalf("expected offset 8 after resumed chunk, got %d", resumed.State.Offset)
+	}
+
+	if _, err := resumed.Commit(); err != nil {
+		t.Fatalf("unexpected error committing upload: %v", err)
+	}
+}
+
+// TestUploadCommitAndCancelSurfaceServerErrors verifies that Commit and
+// Cancel report an error when the server responds with an unexpected
+// status code, instead of treating every response as success.
+func TestUploadCommitAndCancelSurfaceServerErrors(t *testing.T) {
+	key := "failing.bin"
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/uploads/"+key+"/session1")
+			w.Header().Set("Upload-UUID", "session1")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPut:
+			w.WriteHeader(http.StatusConflict)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &ciaos.Config{APIURL: mockServer.URL, UserId: "testuser"}
+
+	upload, err := cfg.StartUpload(key)
+	if err != nil {
+		t.Fatalf("failed to start upload: %v", err)
+	}
+
+	if _, err := upload.Commit(); err == nil {
+		t.Error("expected Commit to report an error for a 409 response")
+	}
+
+	if err := upload.Cancel(); err == nil {
+		t.Error("expected Cancel to report an error for a 404 response")
+	}
+}
@@ -1,13 +1,15 @@
 package handlers
 
 import (
+	"encoding/binary"
 	"fmt"
+	"io"
 
 	flatbuffers "github.com/google/flatbuffers/go"
 	"github.com/vitality-ai/go-sdk/utils/flatbuffer"
 )
 
-func CreateFlatBuffer(dataList [][]byte) ([]byte, error) {
+func CreateFlatBuffer(dataList [][]byte) (data []byte, err error) {
 	// 	Serializes a list of byte arrays into FlatBuffers binary format.
 	//
 	// 	Args:
@@ -18,7 +20,7 @@ func CreateFlatBuffer(dataList [][]byte) ([]byte, error) {
 
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Println("Error creating FlatBuffers data:", r)
+			err = fmt.Errorf("failed to create FlatBuffer data: %v", r)
 		}
 	}()
 
@@ -26,8 +28,8 @@ func CreateFlatBuffer(dataList [][]byte) ([]byte, error) {
 
 	fileDataOffsets := make([]flatbuffers.UOffsetT, len(dataList))
 
-	for i, data := range dataList {
-		dataOffset := builder.CreateByteVector(data)
+	for i, fileData := range dataList {
+		dataOffset := builder.CreateByteVector(fileData)
 		flatbuffer.FileDataStart(builder)
 		flatbuffer.FileDataAddData(builder, dataOffset)
 		fileDataOffsets[i] = flatbuffer.FileDataEnd(builder)
@@ -50,7 +52,75 @@ func CreateFlatBuffer(dataList [][]byte) ([]byte, error) {
 
 }
 
-func GetDataVector(fileDataFBObj *flatbuffer.FileData) ([]byte, error) {
+// CreateFlatBufferChunk serializes a single chunk of a larger upload
+// into a FlatBuffer carrying one FileData entry. Callers streaming a
+// large file can invoke this once per chunk instead of buffering every
+// chunk into a single CreateFlatBuffer call.
+func CreateFlatBufferChunk(chunk []byte) ([]byte, error) {
+	return CreateFlatBuffer([][]byte{chunk})
+}
+
+// WriteFramedChunk writes chunk to w as a single length-prefixed
+// FlatBuffer frame: a 4-byte big-endian length followed by a
+// FlatBuffer carrying one FileData entry. A stream of these frames can
+// be consumed by a FlatBufferReader without ever holding the whole
+// stream in memory at once.
+func WriteFramedChunk(w io.Writer, chunk []byte) error {
+	frame, err := CreateFlatBufferChunk(chunk)
+	if err != nil {
+		return fmt.Errorf("failed to create framed chunk: %v", err)
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint32(len(frame))); err != nil {
+		return fmt.Errorf("failed to write frame length: %v", err)
+	}
+
+	if _, err := w.Write(frame); err != nil {
+		return fmt.Errorf("failed to write frame: %v", err)
+	}
+
+	return nil
+}
+
+// FlatBufferReader incrementally parses a stream of length-prefixed
+// FlatBuffer frames written by WriteFramedChunk, yielding one file's
+// data per Next() call instead of requiring the whole FileDataList to
+// be read into memory up front.
+type FlatBufferReader struct {
+	r io.Reader
+}
+
+// NewFlatBufferReader wraps r, an io.Reader of frames written by
+// WriteFramedChunk.
+func NewFlatBufferReader(r io.Reader) *FlatBufferReader {
+	return &FlatBufferReader{r: r}
+}
+
+// Next returns the next chunk's file data, or io.EOF once the stream
+// is exhausted.
+func (fbr *FlatBufferReader) Next() ([]byte, error) {
+	var frameLen uint32
+	if err := binary.Read(fbr.r, binary.BigEndian, &frameLen); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read frame length: %v", err)
+	}
+
+	frame := make([]byte, frameLen)
+	if _, err := io.ReadFull(fbr.r, frame); err != nil {
+		return nil, fmt.Errorf("failed to read frame: %v", err)
+	}
+
+	chunkFiles, err := ParseFlatBuffer(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	return chunkFiles[0], nil
+}
+
+func GetDataVector(fileDataFBObj *flatbuffer.FileData) (data []byte, err error) {
 	//	Extracts the byte array from a FileData FlatBuffer object.
 	//
 	//	Args:
@@ -61,7 +131,7 @@ func GetDataVector(fileDataFBObj *flatbuffer.FileData) ([]byte, error) {
 
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("Error extracting data vector: %v\n", r)
+			err = fmt.Errorf("failed to extract data vector: %v", r)
 		}
 	}()
 
@@ -71,10 +141,13 @@ func GetDataVector(fileDataFBObj *flatbuffer.FileData) ([]byte, error) {
 
 	dataLen := fileDataFBObj.DataLength()
 	if dataLen == 0 {
-		return nil, fmt.Errorf("no data found")
+		// CreateFlatBuffer/CreateFlatBufferChunk always call
+		// FileDataAddData, even for an empty payload, so a zero-length
+		// vector means a real empty object rather than an absent field.
+		return []byte{}, nil
 	}
 
-	data := make([]byte, dataLen)
+	data = make([]byte, dataLen)
 	for i := 0; i < dataLen; i++ {
 		data[i] = fileDataFBObj.Data(i)
 	}
@@ -82,7 +155,7 @@ func GetDataVector(fileDataFBObj *flatbuffer.FileData) ([]byte, error) {
 	return data, nil
 }
 
-func ParseFlatBuffer(flatBufferData []byte) ([][]byte, error) {
+func ParseFlatBuffer(flatBufferData []byte) (fileDataList [][]byte, err error) {
 	// 	Deserializes FlatBuffers data and extracts file byte arrays.
 	//
 	//	Args:
@@ -92,29 +165,25 @@ func ParseFlatBuffer(flatBufferData []byte) ([][]byte, error) {
 	//   	List[bytes]: List of file data in bytes.
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Println("Error parsing FlatBuffers data:", r)
+			err = fmt.Errorf("failed to parse FlatBuffer data: %v", r)
 		}
 	}()
 
-	fileDataList := [][]byte{}
+	fileDataList = [][]byte{}
 
 	fileDataListFB := flatbuffer.GetRootAsFileDataList(flatBufferData, 0)
 	numFiles := fileDataListFB.FilesLength()
-	fmt.Printf("Number of files: %d\n", numFiles)
 
 	for i := 0; i < numFiles; i++ {
 		var fileDataFBObj flatbuffer.FileData
 		fileDataListFB.Files(&fileDataFBObj, i)
-		dataBytes, err := GetDataVector(&fileDataFBObj)
-		if err != nil {
-			fmt.Printf("No data for file %d: %v\n", i, err)
-			continue
+		dataBytes, dataErr := GetDataVector(&fileDataFBObj)
+		if dataErr != nil {
+			return nil, fmt.Errorf("failed to extract file %d: %v", i, dataErr)
 		}
 
 		fileDataList = append(fileDataList, dataBytes)
-		fmt.Printf("Retrieved file %d, size %d bytes\n", i, len(dataBytes))
 	}
 
-	fmt.Printf("Parsed %d files from FlatBuffer\n", len(fileDataList))
 	return fileDataList, nil
 }
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/vitality-ai/go-sdk/utils/flatbuffer"
+)
+
+// DigestAlgorithm computes a content-addressable digest for a chunk of
+// file data. Sum yields the hex part; digests embedded in a FlatBuffer
+// take the "<Name()>:<hex>" form.
+type DigestAlgorithm interface {
+	// Name is the algorithm identifier used as the digest prefix, e.g. "sha256".
+	Name() string
+	// Sum returns the hex-encoded digest of data.
+	Sum(data []byte) string
+}
+
+type sha256Algorithm struct{}
+
+func (sha256Algorithm) Name() string { return "sha256" }
+
+func (sha256Algorithm) Sum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// SHA256 is the default DigestAlgorithm used by CreateFlatBufferDigest
+// and ParseFlatBufferVerified when no algorithm is supplied.
+var SHA256 DigestAlgorithm = sha256Algorithm{}
+
+var registeredAlgorithms = map[string]DigestAlgorithm{
+	"sha256": SHA256,
+}
+
+// RegisterDigestAlgorithm makes algo available by name to
+// ParseFlatBufferVerified, so callers can opt into algorithms such as
+// blake3 or crc32c without changing this package.
+func RegisterDigestAlgorithm(algo DigestAlgorithm) {
+	registeredAlgorithms[algo.Name()] = algo
+}
+
+func algorithmByName(name string) (DigestAlgorithm, error) {
+	algo, ok := registeredAlgorithms[name]
+	if !ok {
+		return nil, fmt.Errorf("unregistered digest algorithm: %s", name)
+	}
+	return algo, nil
+}
+
+// digestString formats algo's digest of data as "<name>:<hex>".
+func digestString(algo DigestAlgorithm, data []byte) string {
+	return algo.Name() + ":" + algo.Sum(data)
+}
+
+// DigestMismatchError reports that the digest recomputed for a
+// FileData entry on receipt does not match the digest the sender
+// embedded in the FlatBuffer.
+type DigestMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("digest mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// CreateFlatBufferDigest is CreateFlatBuffer, but additionally computes
+// algo's digest of every entry in dataList and embeds it in the
+// FileData's digest field so a receiver can verify integrity with
+// ParseFlatBufferVerified.
+func CreateFlatBufferDigest(dataList [][]byte, algo DigestAlgorithm) (data []byte, err error) {
+
+	if algo == nil {
+		algo = SHA256
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to create FlatBuffer digest data: %v", r)
+		}
+	}()
+
+	builder := flatbuffers.NewBuilder(0)
+
+	fileDataOffsets := make([]flatbuffers.UOffsetT, len(dataList))
+
+	for i, fileData := range dataList {
+		dataOffset := builder.CreateByteVector(fileData)
+		digestOffset := builder.CreateString(digestString(algo, fileData))
+
+		flatbuffer.FileDataStart(builder)
+		flatbuffer.FileDataAddData(builder, dataOffset)
+		flatbuffer.FileDataAddDigest(builder, digestOffset)
+		fileDataOffsets[i] = flatbuffer.FileDataEnd(builder)
+	}
+
+	flatbuffer.FileDataListStartFilesVector(builder, len(fileDataOffsets))
+	for i := len(fileDataOffsets) - 1; i >= 0; i-- {
+		builder.PrependUOffsetT(fileDataOffsets[i])
+	}
+
+	filesVector := builder.EndVector(len(fileDataOffsets))
+	flatbuffer.FileDataListStart(builder)
+	flatbuffer.FileDataListAddFiles(builder, filesVector)
+	fileDataListOffset := flatbuffer.FileDataListEnd(builder)
+	builder.Finish(fileDataListOffset)
+
+	return builder.Bytes[builder.Head():], nil
+}
+
+// ParseFlatBufferVerified is ParseFlatBuffer, but recomputes each
+// entry's digest with the algorithm named by its embedded digest
+// prefix (falling back to defaultAlgo when the prefix names no
+// registered algorithm) and returns a *DigestMismatchError the first
+// time a recomputed digest disagrees with the one the sender embedded.
+// Entries that carry no digest are returned without verification.
+func ParseFlatBufferVerified(flatBufferData []byte, defaultAlgo DigestAlgorithm) ([][]byte, error) {
+
+	if defaultAlgo == nil {
+		defaultAlgo = SHA256
+	}
+
+	fileDataList := [][]byte{}
+
+	fileDataListFB := flatbuffer.GetRootAsFileDataList(flatBufferData, 0)
+	numFiles := fileDataListFB.FilesLength()
+
+	for i := 0; i < numFiles; i++ {
+		var fileDataFBObj flatbuffer.FileData
+		fileDataListFB.Files(&fileDataFBObj, i)
+
+		dataBytes, err := GetDataVector(&fileDataFBObj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract file %d: %v", i, err)
+		}
+
+		expected := string(fileDataFBObj.Digest())
+		if expected != "" {
+			algo := defaultAlgo
+			if idx := strings.IndexByte(expected, ':'); idx >= 0 {
+				if named, err := algorithmByName(expected[:idx]); err == nil {
+					algo = named
+				}
+			}
+
+			actual := digestString(algo, dataBytes)
+			if actual != expected {
+				return fileDataList, &DigestMismatchError{Expected: expected, Actual: actual}
+			}
+		}
+
+		fileDataList = append(fileDataList, dataBytes)
+	}
+
+	return fileDataList, nil
+}